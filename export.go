@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"google.golang.org/api/people/v1"
+
+	"github.com/trickaugusto/gpeople-api-go/pkg/gpeople"
+	"github.com/trickaugusto/gpeople-api-go/pkg/gpeople/vcard"
+)
+
+// runExport grava todos os contatos do usuário em um arquivo, em formato
+// vCard (3.0/4.0) ou CSV compatível com o export do Google Contacts.
+func runExport(ctx context.Context, client *gpeople.Client, args []string) {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	format := fs.String("format", "vcard4", "formato de saída: vcard3, vcard4 ou csv")
+	out := fs.String("out", "contacts.vcf", "arquivo de saída")
+	fs.Parse(args)
+
+	var persons []*people.Person
+	err := client.ListAllConnections(ctx, vcard.PersonFields, func(p *people.Person) error {
+		persons = append(persons, p)
+		return nil
+	})
+	if err != nil {
+		log.Fatalf("Não foi possível listar os contatos: %v", err)
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		log.Fatalf("Não foi possível criar %s: %v", *out, err)
+	}
+	defer f.Close()
+
+	switch *format {
+	case "vcard3":
+		err = vcard.Encode(f, persons, vcard.Version3)
+	case "vcard4":
+		err = vcard.Encode(f, persons, vcard.Version4)
+	case "csv":
+		err = vcard.EncodeCSV(f, persons)
+	default:
+		log.Fatalf("Formato desconhecido: %s", *format)
+	}
+	if err != nil {
+		log.Fatalf("Não foi possível exportar os contatos: %v", err)
+	}
+
+	fmt.Printf("Exportados %d contatos para %s\n", len(persons), *out)
+}
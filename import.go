@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"google.golang.org/api/people/v1"
+
+	"github.com/trickaugusto/gpeople-api-go/pkg/gpeople"
+	"github.com/trickaugusto/gpeople-api-go/pkg/gpeople/vcard"
+)
+
+// runImport lê um arquivo vCard ou CSV e cria um contato no Google
+// Contacts para cada entrada encontrada.
+func runImport(ctx context.Context, client *gpeople.Client, args []string) {
+	fs := flag.NewFlagSet("import", flag.ExitOnError)
+	in := fs.String("in", "", "arquivo de entrada (.vcf ou .csv)")
+	fs.Parse(args)
+
+	if *in == "" {
+		log.Fatal("informe o arquivo de entrada com -in")
+	}
+
+	f, err := os.Open(*in)
+	if err != nil {
+		log.Fatalf("Não foi possível abrir %s: %v", *in, err)
+	}
+	defer f.Close()
+
+	var persons []*people.Person
+	if strings.HasSuffix(strings.ToLower(*in), ".csv") {
+		persons, err = vcard.DecodeCSV(f)
+	} else {
+		persons, err = vcard.Decode(f)
+	}
+	if err != nil {
+		log.Fatalf("Não foi possível interpretar %s: %v", *in, err)
+	}
+
+	created := 0
+	for _, p := range persons {
+		if _, err := client.CreateContact(ctx, p); err != nil {
+			log.Printf("Falha ao importar contato: %v", err)
+			continue
+		}
+		created++
+	}
+	fmt.Printf("Importados %d de %d contatos\n", created, len(persons))
+}
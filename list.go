@@ -0,0 +1,31 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/trickaugusto/gpeople-api-go/pkg/gpeople"
+)
+
+// runList lista os 10 primeiros contatos, o comportamento original da demo.
+func runList(ctx context.Context, client *gpeople.Client) {
+	resp, err := client.ListContacts(ctx, gpeople.ListOptions{
+		PageSize:     10,
+		PersonFields: "names,emailAddresses",
+	})
+	if err != nil {
+		log.Fatalf("Não foi possível recuperar os contatos: %v", err)
+	}
+
+	if len(resp.Connections) > 0 {
+		fmt.Println("Lista dos 10 primeiros contatos:")
+		for _, c := range resp.Connections {
+			if len(c.Names) > 0 {
+				fmt.Println(c.Names[0].DisplayName)
+			}
+		}
+	} else {
+		fmt.Println("Nenhum contato encontrado.")
+	}
+}
@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+
+	"golang.org/x/oauth2"
+)
+
+// NewClient returns an authenticated *http.Client for account, loading a
+// token previously saved in store or running the interactive web flow
+// and persisting the result if none exists yet. The returned client
+// keeps store updated whenever the underlying token gets refreshed, so a
+// long-running program doesn't silently drop a rotated refresh token.
+func NewClient(ctx context.Context, config *oauth2.Config, store TokenStore, account string) (*http.Client, error) {
+	return NewClientWithAuthenticator(ctx, config, store, account, AuthenticateFromWeb)
+}
+
+// NewClientWithAuthenticator is like NewClient but lets the caller
+// supply its own interactive authentication step instead of
+// AuthenticateFromWeb's dedicated server — e.g. one that shares an
+// already-running HTTP listener, like pkg/gateway does.
+func NewClientWithAuthenticator(
+	ctx context.Context,
+	config *oauth2.Config,
+	store TokenStore,
+	account string,
+	authenticate func(*oauth2.Config) (*oauth2.Token, error),
+) (*http.Client, error) {
+	tok, err := store.Load(account)
+	if err != nil {
+		tok, err = authenticate(config)
+		if err != nil {
+			return nil, err
+		}
+		if err := store.Save(account, tok); err != nil {
+			return nil, err
+		}
+	}
+
+	notifying := NewNotifyingTokenSource(
+		config.TokenSource(ctx, tok),
+		func(refreshed *oauth2.Token) error {
+			return store.Save(account, refreshed)
+		},
+	)
+	ts := oauth2.ReuseTokenSource(tok, notifying)
+	return oauth2.NewClient(ctx, ts), nil
+}
@@ -0,0 +1,63 @@
+// Package auth holds OAuth2 helpers shared by the CLI: anti-CSRF state
+// generation, PKCE, and a token source that notifies callers when the
+// underlying token gets refreshed so it can be persisted.
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+
+	"golang.org/x/oauth2"
+)
+
+// GenerateState returns a cryptographically random value suitable for
+// the OAuth2 "state" parameter, used to protect the callback against
+// CSRF. Callers must verify the value echoed back by the provider
+// matches what was generated here before exchanging the code.
+func GenerateState() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("auth: generating state: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// PKCE holds a PKCE code verifier and its derived S256 challenge, as
+// defined in RFC 7636.
+type PKCE struct {
+	Verifier  string
+	Challenge string
+}
+
+// NewPKCE generates a new PKCE verifier/challenge pair.
+func NewPKCE() (*PKCE, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, fmt.Errorf("auth: generating code verifier: %w", err)
+	}
+	verifier := base64.RawURLEncoding.EncodeToString(b)
+
+	sum := sha256.Sum256([]byte(verifier))
+	challenge := base64.RawURLEncoding.EncodeToString(sum[:])
+
+	return &PKCE{Verifier: verifier, Challenge: challenge}, nil
+}
+
+// AuthCodeOptions returns the extra AuthCodeURL options needed to offer
+// this challenge during the authorization request.
+func (p *PKCE) AuthCodeOptions() []oauth2.AuthCodeOption {
+	return []oauth2.AuthCodeOption{
+		oauth2.SetAuthURLParam("code_challenge", p.Challenge),
+		oauth2.SetAuthURLParam("code_challenge_method", "S256"),
+	}
+}
+
+// ExchangeOptions returns the extra Exchange options needed to prove
+// possession of the verifier when trading the code for a token.
+func (p *PKCE) ExchangeOptions() []oauth2.AuthCodeOption {
+	return []oauth2.AuthCodeOption{
+		oauth2.SetAuthURLParam("code_verifier", p.Verifier),
+	}
+}
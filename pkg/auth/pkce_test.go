@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+	"testing"
+
+	"golang.org/x/oauth2"
+)
+
+func TestGenerateStateIsRandomAndURLSafe(t *testing.T) {
+	a, err := GenerateState()
+	if err != nil {
+		t.Fatalf("GenerateState: %v", err)
+	}
+	b, err := GenerateState()
+	if err != nil {
+		t.Fatalf("GenerateState: %v", err)
+	}
+	if a == b {
+		t.Fatal("GenerateState returned the same value twice")
+	}
+	if _, err := base64.RawURLEncoding.DecodeString(a); err != nil {
+		t.Errorf("state %q is not raw-URL-base64: %v", a, err)
+	}
+}
+
+func TestNewPKCEChallengeMatchesVerifier(t *testing.T) {
+	p, err := NewPKCE()
+	if err != nil {
+		t.Fatalf("NewPKCE: %v", err)
+	}
+	if p.Verifier == "" || p.Challenge == "" {
+		t.Fatalf("NewPKCE returned empty fields: %+v", p)
+	}
+
+	sum := sha256.Sum256([]byte(p.Verifier))
+	want := base64.RawURLEncoding.EncodeToString(sum[:])
+	if p.Challenge != want {
+		t.Errorf("Challenge = %q, want S256(Verifier) = %q", p.Challenge, want)
+	}
+}
+
+func TestPKCEOptionsCarryVerifierAndChallenge(t *testing.T) {
+	p, err := NewPKCE()
+	if err != nil {
+		t.Fatalf("NewPKCE: %v", err)
+	}
+
+	cfg := &oauth2.Config{Endpoint: oauth2.Endpoint{AuthURL: "https://example.com/auth"}}
+
+	authOpts := p.AuthCodeOptions()
+	if len(authOpts) != 2 {
+		t.Fatalf("AuthCodeOptions returned %d options, want 2", len(authOpts))
+	}
+	u, err := url.Parse(cfg.AuthCodeURL("state", authOpts...))
+	if err != nil {
+		t.Fatalf("parsing auth URL: %v", err)
+	}
+	if got := u.Query().Get("code_challenge"); got != p.Challenge {
+		t.Errorf("code_challenge = %q, want %q", got, p.Challenge)
+	}
+	if got := u.Query().Get("code_challenge_method"); got != "S256" {
+		t.Errorf("code_challenge_method = %q, want S256", got)
+	}
+
+	exchangeOpts := p.ExchangeOptions()
+	if len(exchangeOpts) != 1 {
+		t.Fatalf("ExchangeOptions returned %d options, want 1", len(exchangeOpts))
+	}
+	u, err = url.Parse(cfg.AuthCodeURL("state", exchangeOpts...))
+	if err != nil {
+		t.Fatalf("parsing URL: %v", err)
+	}
+	if got := u.Query().Get("code_verifier"); got != p.Verifier {
+		t.Errorf("code_verifier = %q, want %q", got, p.Verifier)
+	}
+}
@@ -0,0 +1,104 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// TokenStore persists OAuth2 tokens keyed by account, so a single binary
+// can manage credentials for more than one Google account (the People
+// API itself is per-account: connections, other contacts and directory
+// people all belong to whichever account authorized the request).
+type TokenStore interface {
+	// Load returns the stored token for account, or an error if none
+	// exists yet.
+	Load(account string) (*oauth2.Token, error)
+	// Save persists tok for account, overwriting any previous token.
+	Save(account string, tok *oauth2.Token) error
+	// Delete removes any stored token for account. It is not an error
+	// to delete an account that has no stored token.
+	Delete(account string) error
+	// List returns the accounts with a stored token, sorted.
+	List() ([]string, error)
+}
+
+// FileStore is a TokenStore that keeps one JSON file per account inside
+// Dir, named after the account.
+type FileStore struct {
+	Dir string
+}
+
+// NewFileStore returns a FileStore rooted at dir, creating it if needed.
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("auth: creating token store directory: %w", err)
+	}
+	return &FileStore{Dir: dir}, nil
+}
+
+func (s *FileStore) path(account string) string {
+	return filepath.Join(s.Dir, sanitizeAccount(account)+".json")
+}
+
+// Load implements TokenStore.
+func (s *FileStore) Load(account string) (*oauth2.Token, error) {
+	f, err := os.Open(s.path(account))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	tok := &oauth2.Token{}
+	if err := json.NewDecoder(f).Decode(tok); err != nil {
+		return nil, fmt.Errorf("auth: decoding token for %s: %w", account, err)
+	}
+	return tok, nil
+}
+
+// Save implements TokenStore.
+func (s *FileStore) Save(account string, tok *oauth2.Token) error {
+	f, err := os.OpenFile(s.path(account), os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return fmt.Errorf("auth: saving token for %s: %w", account, err)
+	}
+	defer f.Close()
+	return json.NewEncoder(f).Encode(tok)
+}
+
+// Delete implements TokenStore.
+func (s *FileStore) Delete(account string) error {
+	if err := os.Remove(s.path(account)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("auth: deleting token for %s: %w", account, err)
+	}
+	return nil
+}
+
+// List implements TokenStore.
+func (s *FileStore) List() ([]string, error) {
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("auth: listing token store: %w", err)
+	}
+
+	accounts := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		accounts = append(accounts, strings.TrimSuffix(e.Name(), ".json"))
+	}
+	sort.Strings(accounts)
+	return accounts, nil
+}
+
+// sanitizeAccount makes account safe to use as a filename component.
+func sanitizeAccount(account string) string {
+	replacer := strings.NewReplacer("/", "_", "\\", "_", "..", "_")
+	return replacer.Replace(account)
+}
@@ -0,0 +1,172 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/zalando/go-keyring"
+	"golang.org/x/oauth2"
+)
+
+// keyringService is the OS keyring service name under which the
+// encryption key is stored when GPEOPLE_TOKEN_KEY isn't set.
+const keyringService = "gpeople-api-go"
+
+// tokenKeyEnvVar, when set, must hold a base64-encoded 32-byte AES-256
+// key to use instead of the OS keyring.
+const tokenKeyEnvVar = "GPEOPLE_TOKEN_KEY"
+
+// EncryptedFileStore is a TokenStore like FileStore, except each token
+// is encrypted at rest with AES-256-GCM. The key comes from
+// GPEOPLE_TOKEN_KEY if set, otherwise it's read from (or generated into)
+// the OS keyring.
+type EncryptedFileStore struct {
+	Dir string
+	key []byte
+}
+
+// NewEncryptedFileStore returns an EncryptedFileStore rooted at dir,
+// creating it and resolving the encryption key if needed.
+func NewEncryptedFileStore(dir string) (*EncryptedFileStore, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("auth: creating encrypted token store directory: %w", err)
+	}
+	key, err := loadOrCreateKey()
+	if err != nil {
+		return nil, err
+	}
+	return &EncryptedFileStore{Dir: dir, key: key}, nil
+}
+
+func loadOrCreateKey() ([]byte, error) {
+	if encoded := os.Getenv(tokenKeyEnvVar); encoded != "" {
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("auth: decoding %s: %w", tokenKeyEnvVar, err)
+		}
+		if len(key) != 32 {
+			return nil, fmt.Errorf("auth: %s must decode to 32 bytes, got %d", tokenKeyEnvVar, len(key))
+		}
+		return key, nil
+	}
+
+	switch encoded, err := keyring.Get(keyringService, "token-encryption-key"); {
+	case err == nil:
+		return base64.StdEncoding.DecodeString(encoded)
+	case errors.Is(err, keyring.ErrNotFound):
+		// No key yet: fall through and generate one.
+	default:
+		return nil, fmt.Errorf("auth: reading encryption key from OS keyring: %w", err)
+	}
+
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("auth: generating encryption key: %w", err)
+	}
+	if err := keyring.Set(keyringService, "token-encryption-key", base64.StdEncoding.EncodeToString(key)); err != nil {
+		return nil, fmt.Errorf("auth: storing encryption key in OS keyring: %w", err)
+	}
+	return key, nil
+}
+
+func (s *EncryptedFileStore) path(account string) string {
+	return filepath.Join(s.Dir, sanitizeAccount(account)+".enc")
+}
+
+// Load implements TokenStore.
+func (s *EncryptedFileStore) Load(account string) (*oauth2.Token, error) {
+	ciphertext, err := os.ReadFile(s.path(account))
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := s.decrypt(ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("auth: decrypting token for %s: %w", account, err)
+	}
+
+	tok := &oauth2.Token{}
+	if err := json.Unmarshal(plaintext, tok); err != nil {
+		return nil, fmt.Errorf("auth: decoding token for %s: %w", account, err)
+	}
+	return tok, nil
+}
+
+// Save implements TokenStore.
+func (s *EncryptedFileStore) Save(account string, tok *oauth2.Token) error {
+	plaintext, err := json.Marshal(tok)
+	if err != nil {
+		return fmt.Errorf("auth: encoding token for %s: %w", account, err)
+	}
+	ciphertext, err := s.encrypt(plaintext)
+	if err != nil {
+		return fmt.Errorf("auth: encrypting token for %s: %w", account, err)
+	}
+	return os.WriteFile(s.path(account), ciphertext, 0600)
+}
+
+// Delete implements TokenStore.
+func (s *EncryptedFileStore) Delete(account string) error {
+	if err := os.Remove(s.path(account)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("auth: deleting token for %s: %w", account, err)
+	}
+	return nil
+}
+
+// List implements TokenStore.
+func (s *EncryptedFileStore) List() ([]string, error) {
+	entries, err := os.ReadDir(s.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("auth: listing encrypted token store: %w", err)
+	}
+
+	accounts := make([]string, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".enc") {
+			continue
+		}
+		accounts = append(accounts, strings.TrimSuffix(e.Name(), ".enc"))
+	}
+	sort.Strings(accounts)
+	return accounts, nil
+}
+
+func (s *EncryptedFileStore) encrypt(plaintext []byte) ([]byte, error) {
+	gcm, err := s.gcm()
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return gcm.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func (s *EncryptedFileStore) decrypt(ciphertext []byte) ([]byte, error) {
+	gcm, err := s.gcm()
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < gcm.NonceSize() {
+		return nil, fmt.Errorf("ciphertext shorter than nonce")
+	}
+	nonce, data := ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():]
+	return gcm.Open(nil, nonce, data, nil)
+}
+
+func (s *EncryptedFileStore) gcm() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(s.key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,64 @@
+package auth
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"golang.org/x/oauth2"
+)
+
+// MemoryStore is an in-memory TokenStore. It's mainly useful in tests so
+// they don't have to touch the filesystem.
+type MemoryStore struct {
+	mu     sync.RWMutex
+	tokens map[string]*oauth2.Token
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{tokens: make(map[string]*oauth2.Token)}
+}
+
+// Load implements TokenStore.
+func (s *MemoryStore) Load(account string) (*oauth2.Token, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tok, ok := s.tokens[account]
+	if !ok {
+		return nil, fmt.Errorf("auth: no token stored for %s", account)
+	}
+	return tok, nil
+}
+
+// Save implements TokenStore.
+func (s *MemoryStore) Save(account string, tok *oauth2.Token) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens[account] = tok
+	return nil
+}
+
+// Delete implements TokenStore.
+func (s *MemoryStore) Delete(account string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.tokens, account)
+	return nil
+}
+
+// List implements TokenStore.
+func (s *MemoryStore) List() ([]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	accounts := make([]string, 0, len(s.tokens))
+	for account := range s.tokens {
+		accounts = append(accounts, account)
+	}
+	sort.Strings(accounts)
+	return accounts, nil
+}
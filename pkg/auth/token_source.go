@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"fmt"
+
+	"golang.org/x/oauth2"
+)
+
+// NotifyingTokenSource wraps a TokenSource and invokes onNewToken
+// whenever Token() returns a token the source hasn't handed out before,
+// i.e. whenever the refresh flow minted a new access/refresh token. Wrap
+// it in an oauth2.ReuseTokenSource so refreshes only happen once the
+// current token actually expires.
+type NotifyingTokenSource struct {
+	src           oauth2.TokenSource
+	onNewToken    func(*oauth2.Token) error
+	lastAccessTok string
+}
+
+// NewNotifyingTokenSource wraps src so that onNewToken is called with
+// every newly minted token, letting callers persist rotated refresh
+// tokens instead of silently dropping them.
+func NewNotifyingTokenSource(src oauth2.TokenSource, onNewToken func(*oauth2.Token) error) *NotifyingTokenSource {
+	return &NotifyingTokenSource{src: src, onNewToken: onNewToken}
+}
+
+// Token implements oauth2.TokenSource.
+func (n *NotifyingTokenSource) Token() (*oauth2.Token, error) {
+	tok, err := n.src.Token()
+	if err != nil {
+		return nil, err
+	}
+	if tok.AccessToken != n.lastAccessTok {
+		n.lastAccessTok = tok.AccessToken
+		if n.onNewToken != nil {
+			if err := n.onNewToken(tok); err != nil {
+				return nil, fmt.Errorf("auth: persisting refreshed token: %w", err)
+			}
+		}
+	}
+	return tok, nil
+}
@@ -0,0 +1,171 @@
+package gateway
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/people/v1"
+
+	"github.com/trickaugusto/gpeople-api-go/pkg/gpeople"
+)
+
+func (s *Server) handleContacts(w http.ResponseWriter, r *http.Request) {
+	client, err := s.client(r)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		resp, err := client.ListContacts(r.Context(), gpeople.ListOptions{
+			PageSize:  int64(queryInt(r, "pageSize", 100)),
+			PageToken: r.URL.Query().Get("pageToken"),
+		})
+		if err != nil {
+			writeError(w, http.StatusBadGateway, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, resp)
+
+	case http.MethodPost:
+		var person people.Person
+		if err := json.NewDecoder(r.Body).Decode(&person); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		created, err := client.CreateContact(r.Context(), &person)
+		if err != nil {
+			writeError(w, http.StatusBadGateway, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, created)
+
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleContact(w http.ResponseWriter, r *http.Request) {
+	client, err := s.client(r)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	resourceName := "people/" + strings.TrimPrefix(r.URL.Path, "/v1/contacts/")
+
+	switch r.Method {
+	case http.MethodGet:
+		person, err := client.GetContact(r.Context(), resourceName)
+		if err != nil {
+			writeError(w, http.StatusBadGateway, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, person)
+
+	case http.MethodPut:
+		var person people.Person
+		if err := json.NewDecoder(r.Body).Decode(&person); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		updated, err := client.UpdateContact(r.Context(), resourceName, &person, r.URL.Query().Get("updateMask"))
+		if err != nil {
+			writeError(w, http.StatusBadGateway, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, updated)
+
+	case http.MethodDelete:
+		if err := client.DeleteContact(r.Context(), resourceName); err != nil {
+			writeError(w, http.StatusBadGateway, err)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) handleSearch(w http.ResponseWriter, r *http.Request) {
+	client, err := s.client(r)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	results, err := client.SearchContacts(r.Context(), r.URL.Query().Get("query"))
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, results)
+}
+
+func (s *Server) handleSync(w http.ResponseWriter, r *http.Request) {
+	client, err := s.client(r)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	state := &gpeople.SyncState{NextSyncToken: r.URL.Query().Get("syncToken")}
+
+	var persons []*people.Person
+	err = client.Sync(r.Context(), state, "", func(p *people.Person) error {
+		persons = append(persons, p)
+		return nil
+	})
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, struct {
+		Contacts      []*people.Person `json:"contacts"`
+		NextSyncToken string           `json:"nextSyncToken"`
+	}{persons, state.NextSyncToken})
+}
+
+func (s *Server) handleOtherContacts(w http.ResponseWriter, r *http.Request) {
+	client, err := s.client(r)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp, err := client.ListOtherContacts(r.Context(), "")
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, struct {
+		Error string `json:"error"`
+	}{err.Error()})
+}
+
+func queryInt(r *http.Request, key string, def int) int {
+	v := r.URL.Query().Get(key)
+	if v == "" {
+		return def
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return def
+	}
+	return n
+}
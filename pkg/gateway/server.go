@@ -0,0 +1,158 @@
+// Package gateway exposes pkg/gpeople as a long-lived local JSON HTTP
+// service, so scripts in any language can drive a user's contacts
+// without re-implementing the OAuth dance themselves.
+package gateway
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+
+	"github.com/trickaugusto/gpeople-api-go/pkg/auth"
+	"github.com/trickaugusto/gpeople-api-go/pkg/gpeople"
+)
+
+// ClientFactory returns the gpeople.Client for account, authenticating
+// and caching it however the caller sees fit.
+type ClientFactory func(ctx context.Context, account string) (*gpeople.Client, error)
+
+// Server is a long-lived local HTTP service exposing contact CRUD,
+// search, sync and other-contacts over JSON. Requests are authenticated
+// by a bearer token minted at startup and select which Google account to
+// act on via the X-Account header (defaulting to "default").
+type Server struct {
+	// Token is the bearer token callers must present as
+	// "Authorization: Bearer <Token>".
+	Token string
+
+	clients ClientFactory
+	mux     *http.ServeMux
+
+	// flows holds every OAuth flow currently in flight, keyed by its
+	// anti-CSRF state value. Concurrent AuthenticateViaGateway calls for
+	// different accounts are expected (serve.go locks per account), so a
+	// single unkeyed flow would let one overwrite another and fail the
+	// other's callback with "invalid state".
+	flowMu sync.Mutex
+	flows  map[string]*auth.WebFlow
+}
+
+// NewServer builds a Server backed by clients, minting a random bearer
+// token.
+func NewServer(clients ClientFactory) (*Server, error) {
+	token, err := randomToken()
+	if err != nil {
+		return nil, fmt.Errorf("gateway: generating bearer token: %w", err)
+	}
+
+	s := &Server{Token: token, clients: clients, mux: http.NewServeMux(), flows: make(map[string]*auth.WebFlow)}
+	s.routes()
+	return s, nil
+}
+
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func (s *Server) routes() {
+	s.mux.HandleFunc("/oauth/callback", s.handleOAuthCallback)
+	s.mux.HandleFunc("/v1/contacts", s.requireAuth(s.handleContacts))
+	s.mux.HandleFunc("/v1/contacts/", s.requireAuth(s.handleContact))
+	s.mux.HandleFunc("/v1/contacts:search", s.requireAuth(s.handleSearch))
+	s.mux.HandleFunc("/v1/contacts:sync", s.requireAuth(s.handleSync))
+	s.mux.HandleFunc("/v1/otherContacts", s.requireAuth(s.handleOtherContacts))
+}
+
+// Handler exposes the gateway's mux, e.g. for tests or for embedding in
+// a caller-owned http.Server.
+func (s *Server) Handler() http.Handler {
+	return s.mux
+}
+
+// ListenAndServe starts the gateway on addr, logging the bearer token
+// the caller needs to authenticate requests.
+func (s *Server) ListenAndServe(addr string) error {
+	log.Printf("gateway: listening on %s (bearer token: %s)", addr, s.Token)
+	return http.ListenAndServe(addr, s.mux)
+}
+
+func (s *Server) requireAuth(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+s.Token {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (s *Server) client(r *http.Request) (*gpeople.Client, error) {
+	account := r.Header.Get("X-Account")
+	if account == "" {
+		account = "default"
+	}
+	return s.clients(r.Context(), account)
+}
+
+// handleOAuthCallback forwards to whichever in-flight WebFlow matches
+// the callback's state parameter. It lets AuthenticateViaGateway reuse
+// this server's own listener instead of starting a dedicated one on
+// every new account's first use, and keying by state (rather than
+// keeping one active flow) lets multiple accounts authenticate at once.
+func (s *Server) handleOAuthCallback(w http.ResponseWriter, r *http.Request) {
+	state := r.FormValue("state")
+
+	s.flowMu.Lock()
+	flow := s.flows[state]
+	s.flowMu.Unlock()
+
+	if flow == nil {
+		http.Error(w, "no authorization in progress for this state", http.StatusNotFound)
+		return
+	}
+	flow.HandleCallback(w, r)
+}
+
+// authTimeout bounds how long AuthenticateViaGateway waits for the user
+// to complete the browser flow, matching auth.AuthenticateFromWeb. It's
+// independent of the caller's context, which for a gateway request may
+// otherwise have no deadline at all.
+const authTimeout = 2 * time.Minute
+
+// AuthenticateViaGateway runs an interactive OAuth2 web flow using this
+// server's own listener for the callback at /oauth/callback, rather than
+// standing up a second one on the same port. It's meant to be passed as
+// the authenticator to auth.NewClientWithAuthenticator.
+func (s *Server) AuthenticateViaGateway(ctx context.Context, config *oauth2.Config) (*oauth2.Token, error) {
+	flow, authURL, err := auth.NewWebFlow(config)
+	if err != nil {
+		return nil, err
+	}
+	state := flow.State()
+
+	s.flowMu.Lock()
+	s.flows[state] = flow
+	s.flowMu.Unlock()
+	defer func() {
+		s.flowMu.Lock()
+		delete(s.flows, state)
+		s.flowMu.Unlock()
+	}()
+
+	fmt.Printf("Open the following link in your browser:\n%v\n", authURL)
+
+	ctx, cancel := context.WithTimeout(ctx, authTimeout)
+	defer cancel()
+	return flow.Wait(ctx)
+}
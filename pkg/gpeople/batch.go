@@ -0,0 +1,254 @@
+package gpeople
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/people/v1"
+)
+
+// Per-request limits enforced by the People API's batch RPCs.
+const (
+	maxBatchCreate = 200
+	maxBatchUpdate = 200
+	maxBatchDelete = 500
+)
+
+// BatchResult is the outcome of a single item in a bulk operation: the
+// resulting resource name on success, or the error that item failed
+// with.
+type BatchResult struct {
+	ResourceName string
+	Err          error
+}
+
+// BatchOptions configures the bulk methods below.
+type BatchOptions struct {
+	// Concurrency is how many chunk requests run in parallel. Defaults to 4.
+	Concurrency int
+	// MaxRetries caps retry attempts per chunk on 429/5xx responses. Defaults to 5.
+	MaxRetries int
+}
+
+func (o BatchOptions) withDefaults() BatchOptions {
+	if o.Concurrency <= 0 {
+		o.Concurrency = 4
+	}
+	if o.MaxRetries <= 0 {
+		o.MaxRetries = 5
+	}
+	return o
+}
+
+// ContactUpdate pairs a resource name with the Person data to write to
+// it, for use with BatchUpdateContacts.
+type ContactUpdate struct {
+	ResourceName string
+	Person       *people.Person
+}
+
+// BatchCreateContacts creates persons in chunks of up to the API's
+// per-request limit (200), running chunks concurrently and retrying
+// transient failures with exponential backoff. The returned slice has
+// one entry per input person, in the same order.
+func (c *Client) BatchCreateContacts(ctx context.Context, persons []*people.Person, opts BatchOptions) []BatchResult {
+	opts = opts.withDefaults()
+	results := make([]BatchResult, len(persons))
+
+	runChunked(len(persons), maxBatchCreate, opts.Concurrency, func(start, end int) {
+		contacts := make([]*people.ContactToCreate, end-start)
+		for i := start; i < end; i++ {
+			contacts[i-start] = &people.ContactToCreate{ContactPerson: persons[i]}
+		}
+		req := &people.BatchCreateContactsRequest{
+			Contacts: contacts,
+			ReadMask: DefaultPersonFields,
+		}
+
+		err := withRetry(ctx, opts.MaxRetries, func() error {
+			resp, err := c.svc.People.BatchCreateContacts(req).Context(ctx).Do()
+			if err != nil {
+				return err
+			}
+			for i, created := range resp.CreatedPeople {
+				results[start+i] = personResponseResult(start+i, created)
+			}
+			return nil
+		})
+		if err != nil {
+			fillError(results, start, end, "creating contact", err)
+		}
+	})
+
+	return results
+}
+
+// BatchUpdateContacts updates contacts in chunks of up to the API's
+// per-request limit (200), running chunks concurrently and retrying
+// transient failures with exponential backoff. updateMask lists the
+// person fields being written, as with UpdateContact.
+func (c *Client) BatchUpdateContacts(ctx context.Context, updates []ContactUpdate, updateMask string, opts BatchOptions) []BatchResult {
+	opts = opts.withDefaults()
+	results := make([]BatchResult, len(updates))
+
+	runChunked(len(updates), maxBatchUpdate, opts.Concurrency, func(start, end int) {
+		contacts := make(map[string]people.Person, end-start)
+		for i := start; i < end; i++ {
+			contacts[updates[i].ResourceName] = *updates[i].Person
+		}
+		req := &people.BatchUpdateContactsRequest{
+			Contacts:   contacts,
+			UpdateMask: updateMask,
+			ReadMask:   DefaultPersonFields,
+		}
+
+		err := withRetry(ctx, opts.MaxRetries, func() error {
+			resp, err := c.svc.People.BatchUpdateContacts(req).Context(ctx).Do()
+			if err != nil {
+				return err
+			}
+			for i := start; i < end; i++ {
+				resourceName := updates[i].ResourceName
+				pr, ok := resp.UpdateResult[resourceName]
+				if !ok {
+					results[i] = BatchResult{Err: fmt.Errorf("gpeople: updating contact %s: no result returned", resourceName)}
+					continue
+				}
+				results[i] = personResponseResult(i, &pr)
+			}
+			return nil
+		})
+		if err != nil {
+			fillError(results, start, end, "updating contact", err)
+		}
+	})
+
+	return results
+}
+
+// BatchDeleteContacts deletes contacts in chunks of up to the API's
+// per-request limit (500), running chunks concurrently and retrying
+// transient failures with exponential backoff.
+func (c *Client) BatchDeleteContacts(ctx context.Context, resourceNames []string, opts BatchOptions) []BatchResult {
+	opts = opts.withDefaults()
+	results := make([]BatchResult, len(resourceNames))
+
+	runChunked(len(resourceNames), maxBatchDelete, opts.Concurrency, func(start, end int) {
+		req := &people.BatchDeleteContactsRequest{ResourceNames: resourceNames[start:end]}
+
+		err := withRetry(ctx, opts.MaxRetries, func() error {
+			_, err := c.svc.People.BatchDeleteContacts(req).Context(ctx).Do()
+			return err
+		})
+
+		for i := start; i < end; i++ {
+			if err != nil {
+				results[i] = BatchResult{Err: fmt.Errorf("gpeople: deleting contact %s: %w", resourceNames[i], err)}
+			} else {
+				results[i] = BatchResult{ResourceName: resourceNames[i]}
+			}
+		}
+	})
+
+	return results
+}
+
+// personResponseResult converts a single people.PersonResponse into a
+// BatchResult, labelling any failure with its position (idx) in the
+// input slice for easier debugging.
+func personResponseResult(idx int, pr *people.PersonResponse) BatchResult {
+	if pr.Person != nil {
+		return BatchResult{ResourceName: pr.Person.ResourceName}
+	}
+	if pr.Status != nil && pr.Status.Message != "" {
+		return BatchResult{Err: fmt.Errorf("gpeople: item %d: %s", idx, pr.Status.Message)}
+	}
+	return BatchResult{Err: fmt.Errorf("gpeople: item %d: no person returned", idx)}
+}
+
+func fillError(results []BatchResult, start, end int, verb string, err error) {
+	for i := start; i < end; i++ {
+		results[i] = BatchResult{Err: fmt.Errorf("gpeople: %s: %w", verb, err)}
+	}
+}
+
+// runChunked splits [0, total) into chunks of at most chunkSize items
+// and runs fn on each chunk, up to concurrency chunks at a time.
+func runChunked(total, chunkSize, concurrency int, fn func(start, end int)) {
+	var starts []int
+	for start := 0; start < total; start += chunkSize {
+		starts = append(starts, start)
+	}
+
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for _, start := range starts {
+		end := start + chunkSize
+		if end > total {
+			end = total
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(start, end int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(start, end)
+		}(start, end)
+	}
+	wg.Wait()
+}
+
+// withRetry calls fn, retrying up to maxRetries times with exponential
+// backoff when it fails with a retryable (429/5xx) error.
+func withRetry(ctx context.Context, maxRetries int, fn func() error) error {
+	var err error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		err = fn()
+		if err == nil || !isRetryable(err) {
+			return err
+		}
+		if attempt == maxRetries {
+			break
+		}
+
+		select {
+		case <-time.After(retryDelay(err, attempt)):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return err
+}
+
+func isRetryable(err error) bool {
+	var gerr *googleapi.Error
+	if errors.As(err, &gerr) {
+		return gerr.Code == http.StatusTooManyRequests || gerr.Code >= 500
+	}
+	return false
+}
+
+// retryDelay honors a server-provided Retry-After header when present,
+// falling back to jittered exponential backoff otherwise.
+func retryDelay(err error, attempt int) time.Duration {
+	var gerr *googleapi.Error
+	if errors.As(err, &gerr) && gerr.Header != nil {
+		if ra := gerr.Header.Get("Retry-After"); ra != "" {
+			if secs, parseErr := strconv.Atoi(ra); parseErr == nil {
+				return time.Duration(secs) * time.Second
+			}
+		}
+	}
+
+	base := time.Duration(1<<uint(attempt)) * 200 * time.Millisecond
+	jitter := time.Duration(rand.Int63n(int64(base)/2 + 1))
+	return base + jitter
+}
@@ -0,0 +1,126 @@
+package gpeople
+
+import (
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/people/v1"
+)
+
+func TestRunChunked(t *testing.T) {
+	tests := []struct {
+		name        string
+		total       int
+		chunkSize   int
+		concurrency int
+		wantChunks  [][2]int
+	}{
+		{"empty", 0, 10, 4, nil},
+		{"single partial chunk", 3, 10, 4, [][2]int{{0, 3}}},
+		{"exact multiple", 20, 10, 4, [][2]int{{0, 10}, {10, 20}}},
+		{"trailing partial chunk", 25, 10, 4, [][2]int{{0, 10}, {10, 20}, {20, 25}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var got [][2]int
+
+			runChunked(tt.total, tt.chunkSize, tt.concurrency, func(start, end int) {
+				mu.Lock()
+				got = append(got, [2]int{start, end})
+				mu.Unlock()
+			})
+
+			sort.Slice(got, func(i, j int) bool { return got[i][0] < got[j][0] })
+			if len(got) != len(tt.wantChunks) {
+				t.Fatalf("got %d chunks, want %d: %v", len(got), len(tt.wantChunks), got)
+			}
+			for i, want := range tt.wantChunks {
+				if got[i] != want {
+					t.Errorf("chunk %d = %v, want %v", i, got[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"nil", nil, false},
+		{"not a googleapi error", errors.New("boom"), false},
+		{"429", &googleapi.Error{Code: http.StatusTooManyRequests}, true},
+		{"500", &googleapi.Error{Code: http.StatusInternalServerError}, true},
+		{"503", &googleapi.Error{Code: http.StatusServiceUnavailable}, true},
+		{"404", &googleapi.Error{Code: http.StatusNotFound}, false},
+		{"400", &googleapi.Error{Code: http.StatusBadRequest}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isRetryable(tt.err); got != tt.want {
+				t.Errorf("isRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryDelayHonorsRetryAfter(t *testing.T) {
+	err := &googleapi.Error{
+		Code:   http.StatusTooManyRequests,
+		Header: http.Header{"Retry-After": []string{"5"}},
+	}
+	if got, want := retryDelay(err, 0), 5*time.Second; got != want {
+		t.Errorf("retryDelay() = %v, want %v", got, want)
+	}
+}
+
+func TestRetryDelayBacksOffWithoutRetryAfter(t *testing.T) {
+	err := &googleapi.Error{Code: http.StatusInternalServerError}
+
+	prev := time.Duration(0)
+	for attempt := 0; attempt < 5; attempt++ {
+		d := retryDelay(err, attempt)
+		if d <= 0 {
+			t.Fatalf("retryDelay(attempt=%d) = %v, want > 0", attempt, d)
+		}
+		if attempt > 0 && d <= prev {
+			t.Errorf("retryDelay(attempt=%d) = %v, expected it to grow with attempt (previous delay %v)", attempt, d, prev)
+		}
+		prev = d
+	}
+}
+
+func TestPersonResponseResult(t *testing.T) {
+	t.Run("person present", func(t *testing.T) {
+		pr := &people.PersonResponse{Person: &people.Person{ResourceName: "people/123"}}
+		res := personResponseResult(0, pr)
+		if res.Err != nil || res.ResourceName != "people/123" {
+			t.Errorf("got %+v, want ResourceName=people/123, Err=nil", res)
+		}
+	})
+
+	t.Run("status message", func(t *testing.T) {
+		pr := &people.PersonResponse{Status: &people.Status{Message: "not found"}}
+		res := personResponseResult(1, pr)
+		if res.Err == nil {
+			t.Fatal("expected error for a PersonResponse with only a Status")
+		}
+	})
+
+	t.Run("neither person nor status", func(t *testing.T) {
+		res := personResponseResult(2, &people.PersonResponse{})
+		if res.Err == nil {
+			t.Fatal("expected error for empty PersonResponse")
+		}
+	})
+}
@@ -0,0 +1,141 @@
+// Package gpeople is a thin, reusable wrapper around the Google People
+// API. It exists so that callers don't have to re-implement the
+// boilerplate around contact CRUD on top of people.Service every time;
+// the CLI in this module is just one consumer of it.
+package gpeople
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"google.golang.org/api/option"
+	"google.golang.org/api/people/v1"
+)
+
+// DefaultScope is the OAuth2 scope this package expects callers to
+// request. Unlike people.ContactsReadonlyScope, it allows the create,
+// update and delete operations exposed below.
+const DefaultScope = people.ContactsScope
+
+// DefaultPersonFields is the set of fields fetched when a caller doesn't
+// ask for anything more specific.
+const DefaultPersonFields = "names,emailAddresses,phoneNumbers,organizations"
+
+// Client exposes the contact operations this module needs on top of a
+// people.Service.
+type Client struct {
+	svc *people.Service
+}
+
+// NewClient builds a Client from an already-authenticated HTTP client
+// (typically the result of an oauth2.Config.Client call).
+func NewClient(ctx context.Context, httpClient *http.Client) (*Client, error) {
+	svc, err := people.NewService(ctx, option.WithHTTPClient(httpClient))
+	if err != nil {
+		return nil, fmt.Errorf("gpeople: creating People service: %w", err)
+	}
+	return &Client{svc: svc}, nil
+}
+
+// ListOptions configures a ListContacts call.
+type ListOptions struct {
+	// PageSize is the maximum number of contacts to return; the People
+	// API caps this at 1000.
+	PageSize int64
+	// PageToken resumes a previous listing.
+	PageToken string
+	// PersonFields selects which fields are populated on each returned
+	// Person. Defaults to DefaultPersonFields when empty.
+	PersonFields string
+}
+
+// ListContacts returns a single page of the authenticated user's
+// contacts ("people/me" connections).
+func (c *Client) ListContacts(ctx context.Context, opts ListOptions) (*people.ListConnectionsResponse, error) {
+	fields := opts.PersonFields
+	if fields == "" {
+		fields = DefaultPersonFields
+	}
+
+	call := c.svc.People.Connections.List("people/me").
+		PersonFields(fields).
+		Context(ctx)
+	if opts.PageSize > 0 {
+		call = call.PageSize(opts.PageSize)
+	}
+	if opts.PageToken != "" {
+		call = call.PageToken(opts.PageToken)
+	}
+
+	resp, err := call.Do()
+	if err != nil {
+		return nil, fmt.Errorf("gpeople: listing contacts: %w", err)
+	}
+	return resp, nil
+}
+
+// GetContact fetches a single contact by resource name (e.g.
+// "people/c1234567890").
+func (c *Client) GetContact(ctx context.Context, resourceName string) (*people.Person, error) {
+	person, err := c.svc.People.Get(resourceName).
+		PersonFields(DefaultPersonFields).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, fmt.Errorf("gpeople: getting contact %s: %w", resourceName, err)
+	}
+	return person, nil
+}
+
+// CreateContact creates a new contact from the given Person.
+func (c *Client) CreateContact(ctx context.Context, person *people.Person) (*people.Person, error) {
+	created, err := c.svc.People.CreateContact(person).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("gpeople: creating contact: %w", err)
+	}
+	return created, nil
+}
+
+// UpdateContact updates an existing contact. updateMask lists the
+// person fields being written (e.g. "names,emailAddresses") and must
+// match the People API's updatePersonFields parameter.
+func (c *Client) UpdateContact(ctx context.Context, resourceName string, person *people.Person, updateMask string) (*people.Person, error) {
+	updated, err := c.svc.People.UpdateContact(resourceName, person).
+		UpdatePersonFields(updateMask).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, fmt.Errorf("gpeople: updating contact %s: %w", resourceName, err)
+	}
+	return updated, nil
+}
+
+// DeleteContact deletes a contact by resource name.
+func (c *Client) DeleteContact(ctx context.Context, resourceName string) error {
+	if _, err := c.svc.People.DeleteContact(resourceName).Context(ctx).Do(); err != nil {
+		return fmt.Errorf("gpeople: deleting contact %s: %w", resourceName, err)
+	}
+	return nil
+}
+
+// SearchContacts searches the authenticated user's contacts. Note that
+// the People API requires the contacts cache to be warmed before a
+// search returns results; a freshly-linked account may need a moment
+// after its first ListContacts call.
+func (c *Client) SearchContacts(ctx context.Context, query string) ([]*people.Person, error) {
+	resp, err := c.svc.People.SearchContacts().
+		Query(query).
+		ReadMask(DefaultPersonFields).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, fmt.Errorf("gpeople: searching contacts %q: %w", query, err)
+	}
+
+	results := make([]*people.Person, 0, len(resp.Results))
+	for _, r := range resp.Results {
+		results = append(results, r.Person)
+	}
+	return results, nil
+}
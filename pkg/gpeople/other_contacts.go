@@ -0,0 +1,26 @@
+package gpeople
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/api/people/v1"
+)
+
+// ListOtherContacts returns the user's "other contacts": people they've
+// interacted with (e.g. via Gmail) but never explicitly added as a
+// contact.
+func (c *Client) ListOtherContacts(ctx context.Context, readMask string) (*people.ListOtherContactsResponse, error) {
+	if readMask == "" {
+		readMask = DefaultPersonFields
+	}
+
+	resp, err := c.svc.OtherContacts.List().
+		ReadMask(readMask).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, fmt.Errorf("gpeople: listing other contacts: %w", err)
+	}
+	return resp, nil
+}
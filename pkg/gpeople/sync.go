@@ -0,0 +1,147 @@
+package gpeople
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/api/people/v1"
+)
+
+// ListAllConnections streams every connection for "people/me" across all
+// pages, invoking fn once per person. Iteration stops early if fn
+// returns a non-nil error, which ListAllConnections then returns as-is.
+func (c *Client) ListAllConnections(ctx context.Context, personFields string, fn func(*people.Person) error) error {
+	if personFields == "" {
+		personFields = DefaultPersonFields
+	}
+
+	pageToken := ""
+	for {
+		call := c.svc.People.Connections.List("people/me").
+			PersonFields(personFields).
+			Context(ctx)
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+
+		resp, err := call.Do()
+		if err != nil {
+			return fmt.Errorf("gpeople: listing connections: %w", err)
+		}
+		for _, p := range resp.Connections {
+			if err := fn(p); err != nil {
+				return err
+			}
+		}
+
+		if resp.NextPageToken == "" {
+			return nil
+		}
+		pageToken = resp.NextPageToken
+	}
+}
+
+// SyncState tracks the incremental-sync cursor for a single account's
+// connections. Callers persist it between runs (e.g. with
+// SaveSyncState) so Sync only has to report what changed.
+type SyncState struct {
+	NextSyncToken string `json:"nextSyncToken"`
+}
+
+// LoadSyncState reads a SyncState previously written by SaveSyncState.
+// A missing file is reported as-is (via the underlying os error) so
+// callers can treat it as "no state yet" and fall back to a full sync.
+func LoadSyncState(path string) (*SyncState, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	state := &SyncState{}
+	if err := json.Unmarshal(b, state); err != nil {
+		return nil, fmt.Errorf("gpeople: decoding sync state: %w", err)
+	}
+	return state, nil
+}
+
+// SaveSyncState writes state to path as JSON.
+func SaveSyncState(path string, state *SyncState) error {
+	b, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("gpeople: encoding sync state: %w", err)
+	}
+	if err := os.WriteFile(path, b, 0600); err != nil {
+		return fmt.Errorf("gpeople: writing sync state: %w", err)
+	}
+	return nil
+}
+
+// Sync reports changes to "people/me" connections since state was last
+// updated. On the first call (state.NextSyncToken == "") it streams
+// every connection and requests a sync token; on later calls it streams
+// only what changed since the previous sync, including deletions
+// (surfaced via Person.Metadata.Deleted). state is updated in place once
+// Sync returns successfully; callers are responsible for persisting it.
+//
+// If the server reports the sync token as expired (HTTP 410), state is
+// reset and Sync automatically falls back to a full re-sync.
+func (c *Client) Sync(ctx context.Context, state *SyncState, personFields string, fn func(*people.Person) error) error {
+	if personFields == "" {
+		personFields = DefaultPersonFields
+	}
+
+	pageToken := ""
+	nextSyncToken := ""
+	for {
+		call := c.svc.People.Connections.List("people/me").
+			PersonFields(personFields).
+			RequestSyncToken(true).
+			Context(ctx)
+		if state.NextSyncToken != "" {
+			call = call.SyncToken(state.NextSyncToken)
+		}
+		if pageToken != "" {
+			call = call.PageToken(pageToken)
+		}
+
+		resp, err := call.Do()
+		if err != nil {
+			if state.NextSyncToken != "" && isExpiredSyncToken(err) {
+				state.NextSyncToken = ""
+				return c.Sync(ctx, state, personFields, fn)
+			}
+			return fmt.Errorf("gpeople: syncing connections: %w", err)
+		}
+
+		for _, p := range resp.Connections {
+			if err := fn(p); err != nil {
+				return err
+			}
+		}
+
+		if resp.NextSyncToken != "" {
+			nextSyncToken = resp.NextSyncToken
+		}
+		if resp.NextPageToken == "" {
+			break
+		}
+		pageToken = resp.NextPageToken
+	}
+
+	state.NextSyncToken = nextSyncToken
+	return nil
+}
+
+// isExpiredSyncToken reports whether err is the People API's "sync
+// token expired" error (HTTP 410 Gone).
+func isExpiredSyncToken(err error) bool {
+	var gerr *googleapi.Error
+	if errors.As(err, &gerr) {
+		return gerr.Code == http.StatusGone
+	}
+	return false
+}
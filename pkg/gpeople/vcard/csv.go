@@ -0,0 +1,142 @@
+package vcard
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"google.golang.org/api/people/v1"
+)
+
+// csvHeader lists the columns this package reads and writes. It mirrors
+// the common subset of Google Contacts' own CSV export rather than its
+// full column set, which also covers phonetic names, multiple labeled
+// emails/phones/addresses, IM handles and relations.
+var csvHeader = []string{
+	"First Name", "Last Name", "E-mail 1 - Value", "Phone 1 - Value",
+	"Organization Name", "Organization Title",
+	"Address 1 - Street", "Address 1 - City", "Address 1 - Region",
+	"Address 1 - Postal Code", "Address 1 - Country", "Birthday",
+}
+
+// EncodeCSV writes persons as a Google-Contacts-compatible CSV.
+func EncodeCSV(w io.Writer, persons []*people.Person) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvHeader); err != nil {
+		return fmt.Errorf("vcard: writing CSV header: %w", err)
+	}
+	for _, p := range persons {
+		if err := cw.Write(csvRow(p)); err != nil {
+			return fmt.Errorf("vcard: writing CSV row: %w", err)
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func csvRow(p *people.Person) []string {
+	var firstName, lastName, email, phone, orgName, orgTitle string
+	var street, city, region, postal, country, birthday string
+
+	if len(p.Names) > 0 {
+		firstName = p.Names[0].GivenName
+		lastName = p.Names[0].FamilyName
+	}
+	if len(p.EmailAddresses) > 0 {
+		email = p.EmailAddresses[0].Value
+	}
+	if len(p.PhoneNumbers) > 0 {
+		phone = p.PhoneNumbers[0].Value
+	}
+	if len(p.Organizations) > 0 {
+		orgName = p.Organizations[0].Name
+		orgTitle = p.Organizations[0].Title
+	}
+	if len(p.Addresses) > 0 {
+		a := p.Addresses[0]
+		street, city, region, postal, country = a.StreetAddress, a.City, a.Region, a.PostalCode, a.Country
+	}
+	if len(p.Birthdays) > 0 && p.Birthdays[0].Date != nil {
+		d := p.Birthdays[0].Date
+		birthday = fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+	}
+
+	return []string{firstName, lastName, email, phone, orgName, orgTitle, street, city, region, postal, country, birthday}
+}
+
+// DecodeCSV parses a Google-Contacts-compatible CSV (as written by
+// EncodeCSV) back into *people.Person values.
+func DecodeCSV(r io.Reader) ([]*people.Person, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	rows, err := cr.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("vcard: reading CSV: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	cols := indexHeader(rows[0])
+	result := make([]*people.Person, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		result = append(result, rowToPerson(row, cols))
+	}
+	return result, nil
+}
+
+func indexHeader(header []string) map[string]int {
+	cols := make(map[string]int, len(header))
+	for i, h := range header {
+		cols[h] = i
+	}
+	return cols
+}
+
+func field(row []string, cols map[string]int, name string) string {
+	i, ok := cols[name]
+	if !ok || i >= len(row) {
+		return ""
+	}
+	return row[i]
+}
+
+func rowToPerson(row []string, cols map[string]int) *people.Person {
+	p := &people.Person{}
+
+	first, last := field(row, cols, "First Name"), field(row, cols, "Last Name")
+	if first != "" || last != "" {
+		p.Names = []*people.Name{{GivenName: first, FamilyName: last}}
+	}
+	if email := field(row, cols, "E-mail 1 - Value"); email != "" {
+		p.EmailAddresses = []*people.EmailAddress{{Value: email}}
+	}
+	if phone := field(row, cols, "Phone 1 - Value"); phone != "" {
+		p.PhoneNumbers = []*people.PhoneNumber{{Value: phone}}
+	}
+
+	orgName, orgTitle := field(row, cols, "Organization Name"), field(row, cols, "Organization Title")
+	if orgName != "" || orgTitle != "" {
+		p.Organizations = []*people.Organization{{Name: orgName, Title: orgTitle}}
+	}
+
+	street := field(row, cols, "Address 1 - Street")
+	city := field(row, cols, "Address 1 - City")
+	region := field(row, cols, "Address 1 - Region")
+	postal := field(row, cols, "Address 1 - Postal Code")
+	country := field(row, cols, "Address 1 - Country")
+	if street != "" || city != "" || region != "" || postal != "" || country != "" {
+		p.Addresses = []*people.Address{{
+			StreetAddress: street, City: city, Region: region, PostalCode: postal, Country: country,
+		}}
+	}
+
+	if bday := field(row, cols, "Birthday"); bday != "" {
+		if d := parseBirthday(bday); d != nil {
+			p.Birthdays = []*people.Birthday{d}
+		}
+	}
+
+	return p
+}
@@ -0,0 +1,275 @@
+// Package vcard renders and parses contacts as vCards (versions 3.0 and
+// 4.0, per RFC 6350) and as CSV in the layout Google Contacts exports,
+// so pkg/gpeople can be used to migrate an address book instead of just
+// reading it.
+package vcard
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"google.golang.org/api/people/v1"
+)
+
+// Version selects which vCard version to emit.
+type Version string
+
+const (
+	Version3 Version = "3.0"
+	Version4 Version = "4.0"
+)
+
+// PersonFields is the set of fields a caller should request so Encode
+// and EncodeCSV have everything they render.
+const PersonFields = "names,emailAddresses,phoneNumbers,addresses,organizations,birthdays,photos"
+
+// Encode writes each person in persons as a vCard.
+func Encode(w io.Writer, persons []*people.Person, version Version) error {
+	for _, p := range persons {
+		if err := encodeOne(w, p, version); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func encodeOne(w io.Writer, p *people.Person, version Version) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "BEGIN:VCARD\r\nVERSION:%s\r\n", version)
+
+	fn := ""
+	if len(p.Names) > 0 {
+		n := p.Names[0]
+		fn = n.DisplayName
+		fmt.Fprintf(&b, "N:%s;%s;;;\r\n", escape(n.FamilyName), escape(n.GivenName))
+	}
+	if fn == "" {
+		fn = "Unnamed Contact"
+	}
+	fmt.Fprintf(&b, "FN:%s\r\n", escape(fn))
+
+	for _, e := range p.EmailAddresses {
+		fmt.Fprintf(&b, "EMAIL:%s\r\n", escape(e.Value))
+	}
+	for _, ph := range p.PhoneNumbers {
+		fmt.Fprintf(&b, "TEL:%s\r\n", escape(ph.Value))
+	}
+	for _, a := range p.Addresses {
+		fmt.Fprintf(&b, "ADR:;;%s;%s;%s;%s;%s\r\n",
+			escape(a.StreetAddress), escape(a.City), escape(a.Region), escape(a.PostalCode), escape(a.Country))
+	}
+	for _, o := range p.Organizations {
+		if o.Name != "" {
+			fmt.Fprintf(&b, "ORG:%s\r\n", escape(o.Name))
+		}
+		if o.Title != "" {
+			fmt.Fprintf(&b, "TITLE:%s\r\n", escape(o.Title))
+		}
+	}
+	for _, bd := range p.Birthdays {
+		if bd.Date != nil {
+			fmt.Fprintf(&b, "BDAY:%04d-%02d-%02d\r\n", bd.Date.Year, bd.Date.Month, bd.Date.Day)
+		}
+	}
+	if len(p.Photos) > 0 && p.Photos[0].Url != "" {
+		line, err := encodePhoto(p.Photos[0].Url, version)
+		if err != nil {
+			return err
+		}
+		b.WriteString(line)
+	}
+
+	b.WriteString("END:VCARD\r\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// encodePhoto fetches url and inlines it as a base64 PHOTO property,
+// falling back to a VALUE=URI reference if the fetch fails. The
+// property's form depends on version: 3.0 uses ENCODING=b/TYPE
+// parameters, while 4.0 uses a "data:" URI value instead.
+func encodePhoto(url string, version Version) (string, error) {
+	resp, err := http.Get(url)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return fmt.Sprintf("PHOTO;VALUE=URI:%s\r\n", url), nil
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Sprintf("PHOTO;VALUE=URI:%s\r\n", url), nil
+	}
+
+	mediaType := resp.Header.Get("Content-Type")
+	if mediaType == "" {
+		mediaType = "image/jpeg"
+	}
+	encoded := base64.StdEncoding.EncodeToString(data)
+
+	if version == Version4 {
+		return fmt.Sprintf("PHOTO:data:%s;base64,%s\r\n", mediaType, encoded), nil
+	}
+	return fmt.Sprintf("PHOTO;ENCODING=b;TYPE=%s:%s\r\n", mediaType, encoded), nil
+}
+
+func escape(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `,`, `\,`, `;`, `\;`, "\n", `\n`)
+	return r.Replace(s)
+}
+
+func unescape(s string) string {
+	r := strings.NewReplacer(`\n`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`)
+	return r.Replace(s)
+}
+
+// Decode parses a sequence of vCards into *people.Person values. It
+// reads N, FN, EMAIL, TEL, ADR, ORG, TITLE and BDAY; PHOTO, although
+// Encode writes it, is ignored, as is every other property.
+func Decode(r io.Reader) ([]*people.Person, error) {
+	lines, err := unfoldLines(r)
+	if err != nil {
+		return nil, fmt.Errorf("vcard: reading input: %w", err)
+	}
+
+	var result []*people.Person
+	var cur *people.Person
+
+	for _, line := range lines {
+		switch {
+		case line == "BEGIN:VCARD":
+			cur = &people.Person{}
+		case line == "END:VCARD":
+			if cur != nil {
+				result = append(result, cur)
+				cur = nil
+			}
+		case cur != nil:
+			applyLine(cur, line)
+		}
+	}
+	return result, nil
+}
+
+// unfoldLines reads r and undoes RFC 6350 line folding: a line starting
+// with a single space or tab is a continuation of the previous line, not
+// a property of its own. Without this, folded properties from real
+// vCards (Google, Apple, Outlook all wrap long lines) get truncated or
+// parsed as garbage.
+func unfoldLines(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(line) > 0 && (line[0] == ' ' || line[0] == '\t') && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+func applyLine(p *people.Person, line string) {
+	name, _, value, ok := splitProperty(line)
+	if !ok {
+		return
+	}
+	value = unescape(value)
+
+	switch name {
+	case "FN":
+		ensureName(p).DisplayName = value
+	case "N":
+		parts := strings.Split(value, ";")
+		n := ensureName(p)
+		if len(parts) > 0 {
+			n.FamilyName = parts[0]
+		}
+		if len(parts) > 1 {
+			n.GivenName = parts[1]
+		}
+	case "EMAIL":
+		p.EmailAddresses = append(p.EmailAddresses, &people.EmailAddress{Value: value})
+	case "TEL":
+		p.PhoneNumbers = append(p.PhoneNumbers, &people.PhoneNumber{Value: value})
+	case "ADR":
+		parts := strings.Split(value, ";")
+		addr := &people.Address{}
+		if len(parts) > 2 {
+			addr.StreetAddress = parts[2]
+		}
+		if len(parts) > 3 {
+			addr.City = parts[3]
+		}
+		if len(parts) > 4 {
+			addr.Region = parts[4]
+		}
+		if len(parts) > 5 {
+			addr.PostalCode = parts[5]
+		}
+		if len(parts) > 6 {
+			addr.Country = parts[6]
+		}
+		p.Addresses = append(p.Addresses, addr)
+	case "ORG":
+		ensureOrg(p).Name = value
+	case "TITLE":
+		ensureOrg(p).Title = value
+	case "BDAY":
+		if bd := parseBirthday(value); bd != nil {
+			p.Birthdays = append(p.Birthdays, bd)
+		}
+	}
+}
+
+func ensureName(p *people.Person) *people.Name {
+	if len(p.Names) == 0 {
+		p.Names = append(p.Names, &people.Name{})
+	}
+	return p.Names[0]
+}
+
+func ensureOrg(p *people.Person) *people.Organization {
+	if len(p.Organizations) == 0 {
+		p.Organizations = append(p.Organizations, &people.Organization{})
+	}
+	return p.Organizations[0]
+}
+
+func parseBirthday(value string) *people.Birthday {
+	var y, m, d int
+	if _, err := fmt.Sscanf(value, "%04d-%02d-%02d", &y, &m, &d); err != nil {
+		return nil
+	}
+	return &people.Birthday{Date: &people.Date{Year: int64(y), Month: int64(m), Day: int64(d)}}
+}
+
+// splitProperty splits a single unfolded vCard content line into its
+// property name, parameters and value.
+func splitProperty(line string) (name string, params map[string]string, value string, ok bool) {
+	idx := strings.IndexByte(line, ':')
+	if idx < 0 {
+		return "", nil, "", false
+	}
+	head, value := line[:idx], line[idx+1:]
+
+	parts := strings.Split(head, ";")
+	name = strings.ToUpper(parts[0])
+	params = make(map[string]string, len(parts)-1)
+	for _, p := range parts[1:] {
+		kv := strings.SplitN(p, "=", 2)
+		if len(kv) == 2 {
+			params[strings.ToUpper(kv[0])] = kv[1]
+		}
+	}
+	return name, params, value, true
+}
@@ -0,0 +1,127 @@
+package vcard
+
+import (
+	"strings"
+	"testing"
+
+	"google.golang.org/api/people/v1"
+)
+
+func samplePerson() *people.Person {
+	return &people.Person{
+		Names:          []*people.Name{{GivenName: "Ada", FamilyName: "Lovelace", DisplayName: "Ada Lovelace"}},
+		EmailAddresses: []*people.EmailAddress{{Value: "ada@example.com"}},
+		PhoneNumbers:   []*people.PhoneNumber{{Value: "+1 555 0100"}},
+		Addresses: []*people.Address{{
+			StreetAddress: "1 Analytical Engine Way",
+			City:          "London",
+			Region:        "LDN",
+			PostalCode:    "SW1A",
+			Country:       "UK",
+		}},
+		Organizations: []*people.Organization{{Name: "Analytical Engines, Ltd", Title: "Engineer"}},
+		Birthdays:     []*people.Birthday{{Date: &people.Date{Year: 1815, Month: 12, Day: 10}}},
+	}
+}
+
+func TestEncodeDecodeRoundTrip(t *testing.T) {
+	persons := []*people.Person{samplePerson()}
+
+	var b strings.Builder
+	if err := Encode(&b, persons, Version3); err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+
+	got, err := Decode(strings.NewReader(b.String()))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("Decode returned %d persons, want 1", len(got))
+	}
+
+	p := got[0]
+	if p.Names[0].FamilyName != "Lovelace" || p.Names[0].GivenName != "Ada" {
+		t.Errorf("Names = %+v, want Ada Lovelace", p.Names[0])
+	}
+	if len(p.EmailAddresses) != 1 || p.EmailAddresses[0].Value != "ada@example.com" {
+		t.Errorf("EmailAddresses = %+v", p.EmailAddresses)
+	}
+	if len(p.PhoneNumbers) != 1 || p.PhoneNumbers[0].Value != "+1 555 0100" {
+		t.Errorf("PhoneNumbers = %+v", p.PhoneNumbers)
+	}
+	if len(p.Addresses) != 1 || p.Addresses[0].City != "London" {
+		t.Errorf("Addresses = %+v", p.Addresses)
+	}
+	if len(p.Organizations) != 1 || p.Organizations[0].Name != "Analytical Engines, Ltd" {
+		t.Errorf("Organizations = %+v", p.Organizations)
+	}
+	if len(p.Birthdays) != 1 || p.Birthdays[0].Date.Year != 1815 {
+		t.Errorf("Birthdays = %+v", p.Birthdays)
+	}
+}
+
+func TestDecodeUnfoldsContinuationLines(t *testing.T) {
+	input := "BEGIN:VCARD\r\n" +
+		"VERSION:3.0\r\n" +
+		"N:Lovela\r\n ce;Ada;;;\r\n" +
+		"FN:Ada Lovelace\r\n" +
+		"END:VCARD\r\n"
+
+	got, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("Decode returned %d persons, want 1", len(got))
+	}
+	if want := "Lovelace"; got[0].Names[0].FamilyName != want {
+		t.Errorf("FamilyName = %q, want %q", got[0].Names[0].FamilyName, want)
+	}
+}
+
+func TestDecodeEscaping(t *testing.T) {
+	input := "BEGIN:VCARD\r\n" +
+		"VERSION:3.0\r\n" +
+		"FN:Doe\\, Jane\r\n" +
+		"END:VCARD\r\n"
+
+	got, err := Decode(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if want := "Doe, Jane"; got[0].Names[0].DisplayName != want {
+		t.Errorf("DisplayName = %q, want %q", got[0].Names[0].DisplayName, want)
+	}
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	persons := []*people.Person{samplePerson()}
+
+	var b strings.Builder
+	if err := EncodeCSV(&b, persons); err != nil {
+		t.Fatalf("EncodeCSV: %v", err)
+	}
+
+	got, err := DecodeCSV(strings.NewReader(b.String()))
+	if err != nil {
+		t.Fatalf("DecodeCSV: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("DecodeCSV returned %d persons, want 1", len(got))
+	}
+
+	p := got[0]
+	if p.Names[0].GivenName != "Ada" || p.Names[0].FamilyName != "Lovelace" {
+		t.Errorf("Names = %+v", p.Names[0])
+	}
+	if p.EmailAddresses[0].Value != "ada@example.com" {
+		t.Errorf("EmailAddresses = %+v", p.EmailAddresses)
+	}
+	if p.Organizations[0].Name != "Analytical Engines, Ltd" || p.Organizations[0].Title != "Engineer" {
+		t.Errorf("Organizations = %+v", p.Organizations[0])
+	}
+	if p.Birthdays[0].Date.Year != 1815 || p.Birthdays[0].Date.Month != 12 || p.Birthdays[0].Date.Day != 10 {
+		t.Errorf("Birthdays = %+v", p.Birthdays[0].Date)
+	}
+}
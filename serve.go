@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"log"
+	"sync"
+
+	"golang.org/x/oauth2"
+
+	"github.com/trickaugusto/gpeople-api-go/pkg/auth"
+	"github.com/trickaugusto/gpeople-api-go/pkg/gateway"
+	"github.com/trickaugusto/gpeople-api-go/pkg/gpeople"
+)
+
+// runServe starts the local JSON gateway (pkg/gateway), authenticating
+// accounts lazily and on demand via the X-Account header instead of
+// requiring every account up front.
+func runServe(ctx context.Context, config *oauth2.Config, store auth.TokenStore, args []string) {
+	var (
+		clientsMu sync.Mutex
+		clients   = make(map[string]*gpeople.Client)
+
+		acctLocksMu sync.Mutex
+		acctLocks   = make(map[string]*sync.Mutex)
+
+		srv *gateway.Server
+	)
+
+	// acctLock returns the mutex guarding authentication for account,
+	// creating it on first use. Locking per account (rather than one
+	// mutex for every account) means a stalled interactive OAuth flow
+	// for a new account doesn't block requests for accounts that are
+	// already authenticated.
+	acctLock := func(account string) *sync.Mutex {
+		acctLocksMu.Lock()
+		defer acctLocksMu.Unlock()
+		l, ok := acctLocks[account]
+		if !ok {
+			l = &sync.Mutex{}
+			acctLocks[account] = l
+		}
+		return l
+	}
+
+	factory := func(ctx context.Context, account string) (*gpeople.Client, error) {
+		clientsMu.Lock()
+		client, ok := clients[account]
+		clientsMu.Unlock()
+		if ok {
+			return client, nil
+		}
+
+		lock := acctLock(account)
+		lock.Lock()
+		defer lock.Unlock()
+
+		// Another request may have authenticated this account while we
+		// were waiting for its lock.
+		clientsMu.Lock()
+		client, ok = clients[account]
+		clientsMu.Unlock()
+		if ok {
+			return client, nil
+		}
+
+		httpClient, err := auth.NewClientWithAuthenticator(ctx, config, store, account, func(cfg *oauth2.Config) (*oauth2.Token, error) {
+			return srv.AuthenticateViaGateway(ctx, cfg)
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		client, err = gpeople.NewClient(ctx, httpClient)
+		if err != nil {
+			return nil, err
+		}
+
+		clientsMu.Lock()
+		clients[account] = client
+		clientsMu.Unlock()
+		return client, nil
+	}
+
+	var err error
+	srv, err = gateway.NewServer(factory)
+	if err != nil {
+		log.Fatalf("Não foi possível iniciar o gateway: %v", err)
+	}
+
+	addr := ":8080"
+	if len(args) > 0 {
+		addr = args[0]
+	}
+	if err := srv.ListenAndServe(addr); err != nil {
+		log.Fatalf("Gateway encerrado: %v", err)
+	}
+}